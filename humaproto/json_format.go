@@ -8,28 +8,92 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
-var JSONFormat = huma.Format{
-	Marshal: func(w io.Writer, v any) error {
-		if pv, ok := v.(proto.Message); ok {
-			bytes, err := protojson.MarshalOptions{EmitUnpopulated: true, UseEnumNumbers: true}.Marshal(pv)
-			if err != nil {
+// JSONFormatOptions configures the protojson marshal/unmarshal behavior used
+// by a huma.Format created with NewJSONFormat. The zero value matches
+// protojson's own defaults, except where noted.
+type JSONFormatOptions struct {
+	// EmitUnpopulated specifies whether to emit unpopulated fields. It does
+	// not emit unpopulated oneof fields or unpopulated extension fields.
+	EmitUnpopulated bool
+	// EmitDefaultValues specifies whether to emit default-valued primitive
+	// fields, empty lists, and empty maps, in addition to the fields
+	// EmitUnpopulated already emits.
+	EmitDefaultValues bool
+	// UseEnumNumbers emits enum values as their numeric value instead of the
+	// enum value's name string.
+	UseEnumNumbers bool
+	// UseProtoNames emits field names using the original proto field name
+	// instead of the lowerCamelCase JSON name.
+	UseProtoNames bool
+	// Multiline indents the output JSON for readability.
+	Multiline bool
+	// Indent sets the indent string used when Multiline is true. Defaults to
+	// two spaces if left empty.
+	Indent string
+	// AllowPartial allows messages missing required fields to marshal or
+	// unmarshal without returning an error.
+	AllowPartial bool
+	// DiscardUnknown ignores unknown fields when unmarshalling instead of
+	// returning an error.
+	DiscardUnknown bool
+	// Resolver is used to look up extensions and google.protobuf.Any
+	// message types. Defaults to protoregistry.GlobalTypes if nil.
+	Resolver interface {
+		protoregistry.ExtensionTypeResolver
+		protoregistry.MessageTypeResolver
+	}
+}
+
+// NewJSONFormat creates a huma.Format that marshals and unmarshals
+// proto.Message values with protojson using the given options, falling back
+// to encoding/json for everything else. See JSONFormat for the default
+// instance used for backward compatibility.
+func NewJSONFormat(opts JSONFormatOptions) huma.Format {
+	marshalOpts := protojson.MarshalOptions{
+		Multiline:         opts.Multiline,
+		Indent:            opts.Indent,
+		AllowPartial:      opts.AllowPartial,
+		UseProtoNames:     opts.UseProtoNames,
+		UseEnumNumbers:    opts.UseEnumNumbers,
+		EmitUnpopulated:   opts.EmitUnpopulated,
+		EmitDefaultValues: opts.EmitDefaultValues,
+		Resolver:          opts.Resolver,
+	}
+	unmarshalOpts := protojson.UnmarshalOptions{
+		AllowPartial:   opts.AllowPartial,
+		DiscardUnknown: opts.DiscardUnknown,
+		Resolver:       opts.Resolver,
+	}
+
+	return huma.Format{
+		Marshal: func(w io.Writer, v any) error {
+			if pv, ok := v.(proto.Message); ok {
+				bytes, err := marshalOpts.Marshal(pv)
+				if err != nil {
+					return err
+				}
+				_, err = w.Write(bytes)
 				return err
 			}
-			_, err = w.Write(bytes)
-			return err
-		}
-		return json.NewEncoder(w).Encode(v)
-	},
-	Unmarshal: func(data []byte, v any) error {
-		if reflect.TypeOf(v).Elem().Kind() == reflect.Pointer {
-			rv := reflect.ValueOf(v).Elem()
-			rv.Set(reflect.New(rv.Type().Elem()))
-			if pv, ok := rv.Interface().(proto.Message); ok {
-				return protojson.Unmarshal(data, pv)
+			return json.NewEncoder(w).Encode(v)
+		},
+		Unmarshal: func(data []byte, v any) error {
+			if reflect.TypeOf(v).Elem().Kind() == reflect.Pointer {
+				rv := reflect.ValueOf(v).Elem()
+				rv.Set(reflect.New(rv.Type().Elem()))
+				if pv, ok := rv.Interface().(proto.Message); ok {
+					return unmarshalOpts.Unmarshal(data, pv)
+				}
 			}
-		}
-		return json.Unmarshal(data, v)
-	},
+			return json.Unmarshal(data, v)
+		},
+	}
 }
+
+// JSONFormat is the default huma.Format for protobuf messages: unpopulated
+// fields are emitted and enums are encoded as numbers. Use NewJSONFormat to
+// customize this behavior.
+var JSONFormat = NewJSONFormat(JSONFormatOptions{EmitUnpopulated: true, UseEnumNumbers: true})
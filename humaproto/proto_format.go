@@ -0,0 +1,71 @@
+package humaproto
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/danielgtaylor/huma/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtoBinaryFormat is a huma.Format for application/x-protobuf and
+// application/protobuf that uses the protobuf binary wire format directly
+// via proto.Marshal/proto.Unmarshal. Only proto.Message values can
+// round-trip through it, since there's no general binary encoding for plain
+// Go structs; anything else returns an error.
+var ProtoBinaryFormat = huma.Format{
+	Marshal: func(w io.Writer, v any) error {
+		pv, ok := v.(proto.Message)
+		if !ok {
+			return fmt.Errorf("humaproto: %T does not implement proto.Message, cannot marshal as application/x-protobuf", v)
+		}
+		bytes, err := proto.Marshal(pv)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(bytes)
+		return err
+	},
+	Unmarshal: func(data []byte, v any) error {
+		if reflect.TypeOf(v).Elem().Kind() == reflect.Pointer {
+			rv := reflect.ValueOf(v).Elem()
+			rv.Set(reflect.New(rv.Type().Elem()))
+			if pv, ok := rv.Interface().(proto.Message); ok {
+				return proto.Unmarshal(data, pv)
+			}
+		}
+		return fmt.Errorf("humaproto: %T does not implement proto.Message, cannot unmarshal application/x-protobuf", v)
+	},
+}
+
+// RegisterFormats wires the JSON and binary protobuf formats into cfg so the
+// same handlers can serve both application/json and application/x-protobuf
+// without writing a second handler, matching how gRPC-Gateway style
+// transcoding works. Pass jsonOpts to customize the JSON encoding; the zero
+// value gives protojson's own defaults, not JSONFormat's (which sets
+// EmitUnpopulated and UseEnumNumbers).
+//
+// Call this before huma.NewAPI: a constructed huma.API snapshots its
+// Formats and DefaultFormat at creation time and doesn't expose a way to
+// change them afterwards.
+//
+//	config := huma.DefaultConfig("My API", "1.0.0")
+//	humaproto.RegisterFormats(&config, humaproto.JSONFormatOptions{})
+//	api := humachi.New(router, config)
+func RegisterFormats(cfg *huma.Config, jsonOpts JSONFormatOptions) {
+	jsonFormat := NewJSONFormat(jsonOpts)
+
+	if cfg.Formats == nil {
+		cfg.Formats = map[string]huma.Format{}
+	}
+	cfg.Formats["application/json"] = jsonFormat
+	cfg.Formats["json"] = jsonFormat
+	cfg.Formats["application/x-protobuf"] = ProtoBinaryFormat
+	cfg.Formats["application/protobuf"] = ProtoBinaryFormat
+	cfg.Formats["protobuf"] = ProtoBinaryFormat
+
+	if cfg.DefaultFormat == "" {
+		cfg.DefaultFormat = "application/json"
+	}
+}
@@ -0,0 +1,66 @@
+package humaproto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProtoBinaryFormatRoundTrip(t *testing.T) {
+	want := wrapperspb.String("hello")
+
+	var buf bytes.Buffer
+	if err := ProtoBinaryFormat.Marshal(&buf, want); err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	got := &wrapperspb.StringValue{}
+	if err := ProtoBinaryFormat.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !proto.Equal(want, got) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestProtoBinaryFormatRejectsNonProtoMessage(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ProtoBinaryFormat.Marshal(&buf, "not a proto.Message"); err == nil {
+		t.Error("Marshal() expected error for non-proto.Message value, got nil")
+	}
+
+	var s string
+	if err := ProtoBinaryFormat.Unmarshal([]byte("whatever"), &s); err == nil {
+		t.Error("Unmarshal() expected error for non-proto.Message target, got nil")
+	}
+}
+
+func TestRegisterFormats(t *testing.T) {
+	cfg := huma.Config{}
+
+	RegisterFormats(&cfg, JSONFormatOptions{})
+
+	for _, ct := range []string{"application/json", "json", "application/x-protobuf", "application/protobuf", "protobuf"} {
+		if _, ok := cfg.Formats[ct]; !ok {
+			t.Errorf("expected Formats[%q] to be registered", ct)
+		}
+	}
+
+	if cfg.DefaultFormat != "application/json" {
+		t.Errorf("DefaultFormat = %q, want %q", cfg.DefaultFormat, "application/json")
+	}
+}
+
+func TestRegisterFormatsKeepsExistingDefaultFormat(t *testing.T) {
+	cfg := huma.Config{DefaultFormat: "application/x-protobuf"}
+
+	RegisterFormats(&cfg, JSONFormatOptions{})
+
+	if cfg.DefaultFormat != "application/x-protobuf" {
+		t.Errorf("DefaultFormat = %q, want unchanged %q", cfg.DefaultFormat, "application/x-protobuf")
+	}
+}
@@ -14,9 +14,45 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 )
 
+// RegistryOptions configures schema generation behavior that needs to stay
+// consistent with the protojson.MarshalOptions used to encode the same
+// messages on the wire, such as field naming.
+type RegistryOptions struct {
+	// UseProtoNames emits schema property names (and the required list)
+	// using the original protobuf field name instead of the default
+	// camelCased JSON name, matching protojson.MarshalOptions.UseProtoNames.
+	UseProtoNames bool
+	// UseEnumNumbers emits proto enum schemas as integers with a numeric
+	// `enum` list instead of strings, matching
+	// protojson.MarshalOptions.UseEnumNumbers.
+	UseEnumNumbers bool
+}
+
 func NewRegistry(prefix string, namer func(t reflect.Type, hint string) string) huma.Registry {
+	return NewRegistryWithOptions(prefix, namer, RegistryOptions{})
+}
+
+// RegistryOptionsFromJSONFormat derives the RegistryOptions that keep schema
+// generation consistent with jsonOpts, a JSONFormatOptions used to build the
+// wire-format huma.Format with NewJSONFormat. It only looks at the fields
+// the two types have in common (UseProtoNames, UseEnumNumbers); the rest of
+// JSONFormatOptions doesn't affect schema generation. Prefer this over
+// constructing RegistryOptions by hand so the schema and the wire format
+// can't drift apart.
+func RegistryOptionsFromJSONFormat(jsonOpts JSONFormatOptions) RegistryOptions {
+	return RegistryOptions{
+		UseProtoNames:  jsonOpts.UseProtoNames,
+		UseEnumNumbers: jsonOpts.UseEnumNumbers,
+	}
+}
+
+// NewRegistryWithOptions is like NewRegistry but allows configuring schema
+// generation to match non-default JSONFormatOptions, e.g. when UseProtoNames
+// is enabled on the wire format.
+func NewRegistryWithOptions(prefix string, namer func(t reflect.Type, hint string) string, opts RegistryOptions) huma.Registry {
 	return &protoJSONHumaRegistry{
 		prefix: prefix,
+		opts:   opts,
 
 		schemas: map[string]*huma.Schema{},
 		types:   map[string]reflect.Type{},
@@ -28,6 +64,7 @@ func NewRegistry(prefix string, namer func(t reflect.Type, hint string) string)
 
 type protoJSONHumaRegistry struct {
 	prefix string
+	opts   RegistryOptions
 
 	schemas map[string]*huma.Schema
 	types   map[string]reflect.Type
@@ -36,6 +73,21 @@ type protoJSONHumaRegistry struct {
 	aliases map[reflect.Type]reflect.Type
 }
 
+// useProtoNames reports whether the schema generator should use the
+// original protobuf field name instead of the camelCased JSON name. It's
+// consulted via an optional interface from schemaFromType so the package
+// function doesn't need a reference to the concrete registry type.
+func (r *protoJSONHumaRegistry) useProtoNames() bool {
+	return r.opts.UseProtoNames
+}
+
+// useEnumNumbers reports whether the schema generator should describe proto
+// enums as integers instead of strings. It's consulted via an optional
+// interface from schemaFromType, the same way useProtoNames is.
+func (r *protoJSONHumaRegistry) useEnumNumbers() bool {
+	return r.opts.UseEnumNumbers
+}
+
 var (
 	timeType       = reflect.TypeOf(time.Time{})
 	ipType         = reflect.TypeOf(net.IP{})
@@ -70,6 +122,20 @@ func (r *protoJSONHumaRegistry) Schema(t reflect.Type, allowRef bool, hint strin
 		// Special case: time.Time is always a string.
 		getsRef = false
 	}
+	if isWellKnownProtoType(t) {
+		// Special case: well-known protobuf types have a canonical protojson
+		// encoding that doesn't match their Go struct fields, so they're
+		// treated like scalars instead of being recursed into or ref'd.
+		getsRef = false
+	}
+
+	enumDesc, isEnum := protoEnumDescriptor(t)
+	if isEnum {
+		// Proto enums are plain ints in Go, but they're named, finite, and
+		// reused across messages just like message types, so they get a ref
+		// too, keyed by their full proto name instead of the namer's choice.
+		getsRef = true
+	}
 
 	v := reflect.New(t).Interface()
 	if _, ok := v.(huma.SchemaProvider); ok {
@@ -83,6 +149,9 @@ func (r *protoJSONHumaRegistry) Schema(t reflect.Type, allowRef bool, hint strin
 	}
 
 	name := r.namer(origType, hint)
+	if isEnum {
+		name = string(enumDesc.FullName())
+	}
 
 	if getsRef {
 		if s, ok := r.schemas[name]; ok {
@@ -0,0 +1,14 @@
+package humaproto
+
+import "testing"
+
+func TestRegistryOptionsFromJSONFormat(t *testing.T) {
+	jsonOpts := JSONFormatOptions{UseProtoNames: true, UseEnumNumbers: true, Multiline: true}
+
+	got := RegistryOptionsFromJSONFormat(jsonOpts)
+	want := RegistryOptions{UseProtoNames: true, UseEnumNumbers: true}
+
+	if got != want {
+		t.Errorf("RegistryOptionsFromJSONFormat(%+v) = %+v, want %+v", jsonOpts, got, want)
+	}
+}
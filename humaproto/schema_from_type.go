@@ -14,8 +14,106 @@ import (
 	"unicode/utf8"
 
 	"github.com/danielgtaylor/huma/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+var (
+	timestampType = reflect.TypeOf(timestamppb.Timestamp{})
+	durationType  = reflect.TypeOf(durationpb.Duration{})
+	fieldMaskType = reflect.TypeOf(fieldmaskpb.FieldMask{})
+	structType    = reflect.TypeOf(structpb.Struct{})
+	valueType     = reflect.TypeOf(structpb.Value{})
+	listValueType = reflect.TypeOf(structpb.ListValue{})
+	emptyType     = reflect.TypeOf(emptypb.Empty{})
+	anyType       = reflect.TypeOf(anypb.Any{})
+
+	stringValueType = reflect.TypeOf(wrapperspb.StringValue{})
+	bytesValueType  = reflect.TypeOf(wrapperspb.BytesValue{})
+	boolValueType   = reflect.TypeOf(wrapperspb.BoolValue{})
+	int32ValueType  = reflect.TypeOf(wrapperspb.Int32Value{})
+	uint32ValueType = reflect.TypeOf(wrapperspb.UInt32Value{})
+	int64ValueType  = reflect.TypeOf(wrapperspb.Int64Value{})
+	uint64ValueType = reflect.TypeOf(wrapperspb.UInt64Value{})
+	floatValueType  = reflect.TypeOf(wrapperspb.FloatValue{})
+	doubleValueType = reflect.TypeOf(wrapperspb.DoubleValue{})
+)
+
+// isWellKnownProtoType returns true if t is one of the protobuf well-known
+// types (google.golang.org/protobuf/types/known/...) that protojson encodes
+// using a canonical JSON shape rather than the Go struct's own fields.
+func isWellKnownProtoType(t reflect.Type) bool {
+	switch t {
+	case timestampType, durationType, fieldMaskType, structType, valueType, listValueType, emptyType, anyType,
+		stringValueType, bytesValueType, boolValueType, int32ValueType, uint32ValueType, int64ValueType, uint64ValueType, floatValueType, doubleValueType:
+		return true
+	}
+	return false
+}
+
+// wellKnownProtoSchema builds the huma.Schema for a protobuf well-known type,
+// matching the shape protojson produces on the wire rather than the Go
+// struct's own fields. The second return value is false if t isn't one of
+// the recognised well-known types.
+func wellKnownProtoSchema(t reflect.Type, isPointer bool) (*huma.Schema, bool) {
+	minZero := 0.0
+
+	switch t {
+	case timestampType:
+		return &huma.Schema{Type: huma.TypeString, Nullable: isPointer, Format: "date-time"}, true
+	case durationType:
+		return &huma.Schema{Type: huma.TypeString, Nullable: isPointer, Format: "duration"}, true
+	case fieldMaskType:
+		// Encoded as a single comma-separated string of field paths.
+		return &huma.Schema{Type: huma.TypeString, Nullable: isPointer}, true
+	case structType:
+		return &huma.Schema{Type: huma.TypeObject, Nullable: isPointer, AdditionalProperties: true}, true
+	case valueType:
+		// Any JSON value at all, so no constraints on the schema.
+		return &huma.Schema{Nullable: true}, true
+	case listValueType:
+		return &huma.Schema{Type: huma.TypeArray, Nullable: isPointer, Items: &huma.Schema{}}, true
+	case emptyType:
+		return &huma.Schema{Type: huma.TypeObject, Nullable: isPointer, AdditionalProperties: false}, true
+	case anyType:
+		return &huma.Schema{
+			Type:     huma.TypeObject,
+			Nullable: isPointer,
+			Properties: map[string]*huma.Schema{
+				"@type": {Type: huma.TypeString},
+			},
+			Required:             []string{"@type"},
+			AdditionalProperties: true,
+		}, true
+	case stringValueType:
+		return &huma.Schema{Type: huma.TypeString, Nullable: true}, true
+	case bytesValueType:
+		return &huma.Schema{Type: huma.TypeString, Nullable: true, ContentEncoding: "base64"}, true
+	case boolValueType:
+		return &huma.Schema{Type: huma.TypeBoolean, Nullable: true}, true
+	case int32ValueType:
+		return &huma.Schema{Type: huma.TypeInteger, Nullable: true, Format: "int32"}, true
+	case uint32ValueType:
+		return &huma.Schema{Type: huma.TypeInteger, Nullable: true, Format: "int32", Minimum: &minZero}, true
+	case int64ValueType, uint64ValueType:
+		// Int64 in protojson are strings, same as plain int64/uint64 fields.
+		return &huma.Schema{Type: huma.TypeString, Nullable: true}, true
+	case floatValueType:
+		return &huma.Schema{Type: huma.TypeNumber, Nullable: true, Format: "float"}, true
+	case doubleValueType:
+		return &huma.Schema{Type: huma.TypeNumber, Nullable: true, Format: "double"}, true
+	}
+	return nil, false
+}
+
 func SchemaFromType(r huma.Registry, t reflect.Type) *huma.Schema {
 	s := schemaFromType(r, t)
 	t = deref(t)
@@ -28,7 +126,121 @@ func SchemaFromType(r huma.Registry, t reflect.Type) *huma.Schema {
 	return s
 }
 
-var protobufNameRegex = regexp.MustCompile(`^.*name\=([a-zA-Z0-9]+)\,.*$`)
+var protobufNameRegex = regexp.MustCompile(`^.*name\=([a-zA-Z0-9_]+)\,.*$`)
+var protobufJSONNameRegex = regexp.MustCompile(`^.*json\=([a-zA-Z0-9_]+)\,.*$`)
+
+// useProtoNames reports whether r wants schema property names (and the
+// required list) to use the original protobuf field name instead of the
+// camelCased JSON name, matching the same choice made when marshalling with
+// protojson.MarshalOptions.UseProtoNames. Registries that don't care about
+// this (e.g. hand-rolled huma.Registry implementations) default to false.
+func useProtoNames(r huma.Registry) bool {
+	if pn, ok := r.(interface{ useProtoNames() bool }); ok {
+		return pn.useProtoNames()
+	}
+	return false
+}
+
+// useEnumNumbers reports whether r wants proto enum schemas described as
+// integers instead of strings, matching the same choice made when
+// marshalling with protojson.MarshalOptions.UseEnumNumbers.
+func useEnumNumbers(r huma.Registry) bool {
+	if en, ok := r.(interface{ useEnumNumbers() bool }); ok {
+		return en.useEnumNumbers()
+	}
+	return false
+}
+
+// protoEnumDescriptor reports whether t is a protobuf enum type (a named
+// int32 type generated by protoc-gen-go) by checking whether it implements
+// protoreflect.Enum, and returns its descriptor if so.
+func protoEnumDescriptor(t reflect.Type) (protoreflect.EnumDescriptor, bool) {
+	v := reflect.New(t).Interface()
+	if e, ok := v.(protoreflect.Enum); ok {
+		return e.Descriptor(), true
+	}
+	return nil, false
+}
+
+// enumSchema builds the schema for a protobuf enum type. Its Go
+// representation is a plain int32 with no enum values unless we consult the
+// descriptor, which also lets us emit the variant names either as the enum
+// values themselves (strings) or as the `x-enum-varnames` extension
+// alongside the numeric values, depending on useEnumNumbers.
+func enumSchema(r huma.Registry, desc protoreflect.EnumDescriptor, isPointer bool) *huma.Schema {
+	values := desc.Values()
+
+	if useEnumNumbers(r) {
+		nums := make([]any, 0, values.Len())
+		varNames := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			ev := values.Get(i)
+			nums = append(nums, int64(ev.Number()))
+			varNames = append(varNames, string(ev.Name()))
+		}
+		return &huma.Schema{
+			Type:       huma.TypeInteger,
+			Format:     "int32",
+			Nullable:   isPointer,
+			Enum:       nums,
+			Extensions: map[string]any{"x-enum-varnames": varNames},
+		}
+	}
+
+	names := make([]any, 0, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		names = append(names, string(values.Get(i).Name()))
+	}
+	return &huma.Schema{
+		Type:     huma.TypeString,
+		Nullable: isPointer,
+		Enum:     names,
+	}
+}
+
+// oneofFieldSchema builds the schema for one variant of a proto oneof
+// straight from its field descriptor, mirroring the reflect.Kind switch in
+// schemaFromType but driven by protoreflect.Kind instead, since oneof
+// variants aren't reachable as ordinary Go struct fields. parentName seeds
+// the ref name used for message-typed variants. Panics if fd is a
+// message/group field whose type isn't registered with
+// protoregistry.GlobalTypes, since that means the variant's package was
+// never imported for its side-effecting init() registration rather than
+// anything a caller can recover from.
+func oneofFieldSchema(r huma.Registry, parentName string, fd protoreflect.FieldDescriptor) *huma.Schema {
+	minZero := 0.0
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return &huma.Schema{Type: huma.TypeBoolean}
+	case protoreflect.StringKind:
+		return &huma.Schema{Type: huma.TypeString}
+	case protoreflect.BytesKind:
+		return &huma.Schema{Type: huma.TypeString, ContentEncoding: "base64"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &huma.Schema{Type: huma.TypeInteger, Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &huma.Schema{Type: huma.TypeInteger, Format: "int32", Minimum: &minZero}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// Int64 in protojson are strings.
+		return &huma.Schema{Type: huma.TypeString}
+	case protoreflect.FloatKind:
+		return &huma.Schema{Type: huma.TypeNumber, Format: "float"}
+	case protoreflect.DoubleKind:
+		return &huma.Schema{Type: huma.TypeNumber, Format: "double"}
+	case protoreflect.EnumKind:
+		return enumSchema(r, fd.Enum(), false)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		mt, err := protoregistry.GlobalTypes.FindMessageByName(fd.Message().FullName())
+		if err != nil {
+			panic(fmt.Errorf("humaproto: resolve message type for oneof field %q: %w", fd.FullName(), err))
+		}
+		cft := reflect.TypeOf(mt.New().Interface())
+		return r.Schema(cft, true, parentName+cft.Name()+"Struct")
+	}
+	return nil
+}
 
 func schemaFromType(r huma.Registry, t reflect.Type) *huma.Schema {
 	isPointer := t.Kind() == reflect.Pointer
@@ -60,6 +272,10 @@ func schemaFromType(r huma.Registry, t reflect.Type) *huma.Schema {
 		return &huma.Schema{}
 	}
 
+	if ws, ok := wellKnownProtoSchema(t, isPointer); ok {
+		return ws
+	}
+
 	if _, ok := v.(encoding.TextUnmarshaler); ok {
 		// Special case: types that implement encoding.TextUnmarshaler are able to
 		// be loaded from plain text, and so should be treated as strings.
@@ -68,6 +284,13 @@ func schemaFromType(r huma.Registry, t reflect.Type) *huma.Schema {
 		return &huma.Schema{Type: huma.TypeString, Nullable: isPointer}
 	}
 
+	if desc, ok := protoEnumDescriptor(t); ok {
+		// Proto enums are generated as named int32 types (e.g. `type Status
+		// int32`) which would otherwise fall through to the plain integer
+		// case below with no enum values.
+		return enumSchema(r, desc, isPointer)
+	}
+
 	minZero := 0.0
 	switch t.Kind() {
 	case reflect.Bool:
@@ -150,6 +373,43 @@ func schemaFromType(r huma.Registry, t reflect.Type) *huma.Schema {
 			}
 
 			if j := f.Tag.Get("protobuf_oneof"); j != "" {
+				if pm, ok := v.(proto.Message); ok {
+					if oneofDesc := pm.ProtoReflect().Descriptor().Oneofs().ByName(protoreflect.Name(j)); oneofDesc != nil {
+						// Preferred path: walk the real oneof descriptor so
+						// variants from other oneofs on the same message
+						// can't be swept in, and the variant schema is built
+						// straight from the field descriptor's kind instead
+						// of guessing a Get* accessor name to reflect on.
+						oneofFields := oneofDesc.Fields()
+						for i := 0; i < oneofFields.Len(); i++ {
+							fd := oneofFields.Get(i)
+
+							fs := oneofFieldSchema(r, t.Name(), fd)
+							if fs == nil {
+								continue
+							}
+
+							ignoreAdditionalProperties = true
+							propName := fd.JSONName()
+							if useProtoNames(r) {
+								propName = string(fd.Name())
+							}
+							s.OneOf = append(s.OneOf, &huma.Schema{
+								Type: "object",
+								Properties: map[string]*huma.Schema{
+									propName: fs,
+								},
+								Required: []string{propName},
+							})
+						}
+
+						continue
+					}
+				}
+
+				// Fallback for hand-written structs that aren't real proto
+				// messages: treat every Get* accessor that isn't already one
+				// of the struct's own fields as a oneof variant.
 				for i := range oriT.NumMethod() {
 					m := oriT.Method(i)
 
@@ -208,8 +468,24 @@ func schemaFromType(r huma.Registry, t reflect.Type) *huma.Schema {
 
 			name := f.Name
 			if j := f.Tag.Get("protobuf"); j != "" {
-				if n := protobufNameRegex.FindStringSubmatch(j)[1]; n != "" {
-					name = n
+				if useProtoNames(r) {
+					// UseProtoNames picks the original proto field name
+					// instead of the camelCased JSON name, matching
+					// protojson.MarshalOptions.UseProtoNames.
+					if m := protobufNameRegex.FindStringSubmatch(j); m != nil && m[1] != "" {
+						name = m[1]
+					}
+				} else if m := protobufJSONNameRegex.FindStringSubmatch(j); m != nil && m[1] != "" {
+					name = m[1]
+				} else if m := protobufNameRegex.FindStringSubmatch(j); m != nil && m[1] != "" {
+					// protojson's default (non-UseProtoNames) output is the
+					// camelCased JSON name, but protoc-gen-go only emits a
+					// `json=` component when it differs from `name=`, which
+					// it omits for single-word fields (e.g. "status") since
+					// camelCase and the proto name are identical there -
+					// fall back to `name=` so those fields aren't left
+					// capitalized like the raw Go struct field name.
+					name = m[1]
 				}
 				fieldRequired = !slices.Contains(strings.Split(j, ","), "oneof")
 			} else if j := f.Tag.Get("json"); j != "" {
@@ -0,0 +1,273 @@
+package humaproto
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type fieldNamingMessage struct {
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func TestSchemaFromTypeFieldNaming(t *testing.T) {
+	namer := func(t reflect.Type, hint string) string { return t.Name() }
+
+	tests := []struct {
+		name          string
+		useProtoNames bool
+		want          map[string]bool
+	}{
+		{
+			name:          "default honours the json= camelCase name",
+			useProtoNames: false,
+			want:          map[string]bool{"status": true, "userId": true},
+		},
+		{
+			name:          "UseProtoNames picks the name= proto field name",
+			useProtoNames: true,
+			want:          map[string]bool{"status": true, "user_id": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistryWithOptions("#/components/schemas/", namer, RegistryOptions{UseProtoNames: tt.useProtoNames})
+			s := r.Schema(reflect.TypeOf(fieldNamingMessage{}), false, "fieldNamingMessage")
+
+			for want := range tt.want {
+				if _, ok := s.Properties[want]; !ok {
+					t.Errorf("expected property %q in schema, got properties %v", want, propertyNames(s))
+				}
+				if !contains(s.Required, want) {
+					t.Errorf("expected %q in required list, got %v", want, s.Required)
+				}
+			}
+		})
+	}
+}
+
+// namingMessageDescriptor builds a real protoreflect.MessageDescriptor for
+// a message with the same shape as fieldNamingMessage (one single-word
+// field, one multi-word field), so tests can marshal it with real protojson
+// instead of asserting against this package's own (possibly wrong) notion
+// of what protojson does.
+func namingMessageDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	fdProto := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("humaproto_test/naming_message.proto"),
+		Package: proto.String("humaproto.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("NamingMessage"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("status"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						JsonName: proto.String("status"),
+					},
+					{
+						Name:     proto.String("user_id"),
+						Number:   proto.Int32(2),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						JsonName: proto.String("userId"),
+					},
+				},
+			},
+		},
+	}
+
+	fd, err := protodesc.NewFile(fdProto, &protoregistry.Files{})
+	if err != nil {
+		t.Fatalf("protodesc.NewFile() error = %v", err)
+	}
+	return fd.Messages().Get(0)
+}
+
+// TestSchemaFromTypeFieldNamingMatchesProtojson round-trips a multi-word
+// field through real protojson.Marshal (via a dynamicpb message built from
+// a genuine descriptor, not the hand-rolled fieldNamingMessage struct) and
+// checks the schema's property/required names agree with the JSON keys
+// protojson actually produces, for both UseProtoNames settings.
+func TestSchemaFromTypeFieldNamingMatchesProtojson(t *testing.T) {
+	md := namingMessageDescriptor(t)
+	msg := dynamicpb.NewMessage(md)
+	msg.Set(md.Fields().ByName("status"), protoreflect.ValueOfString("active"))
+	msg.Set(md.Fields().ByName("user_id"), protoreflect.ValueOfString("u-1"))
+
+	namer := func(t reflect.Type, hint string) string { return t.Name() }
+
+	for _, useProtoNames := range []bool{false, true} {
+		t.Run(map[bool]string{false: "default", true: "UseProtoNames"}[useProtoNames], func(t *testing.T) {
+			format := NewJSONFormat(JSONFormatOptions{UseProtoNames: useProtoNames})
+
+			var buf bytes.Buffer
+			if err := format.Marshal(&buf, msg.Interface()); err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			var wire map[string]json.RawMessage
+			if err := json.Unmarshal(buf.Bytes(), &wire); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			r := NewRegistryWithOptions("#/components/schemas/", namer, RegistryOptions{UseProtoNames: useProtoNames})
+			s := r.Schema(reflect.TypeOf(fieldNamingMessage{}), false, "fieldNamingMessage")
+
+			for key := range wire {
+				if _, ok := s.Properties[key]; !ok {
+					t.Errorf("protojson emitted key %q but schema properties are %v", key, propertyNames(s))
+				}
+				if !contains(s.Required, key) {
+					t.Errorf("protojson emitted key %q but schema required list is %v", key, s.Required)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaFromTypeWellKnownTypes(t *testing.T) {
+	namer := func(t reflect.Type, hint string) string { return t.Name() }
+	r := NewRegistry("#/components/schemas/", namer)
+
+	tests := []struct {
+		name       string
+		t          reflect.Type
+		wantType   string
+		wantFormat string
+	}{
+		{"timestamppb.Timestamp", reflect.TypeOf(timestamppb.Timestamp{}), huma.TypeString, "date-time"},
+		{"durationpb.Duration", reflect.TypeOf(durationpb.Duration{}), huma.TypeString, "duration"},
+		{"wrapperspb.Int32Value", reflect.TypeOf(wrapperspb.Int32Value{}), huma.TypeInteger, "int32"},
+		{
+			// protojson encodes int64/uint64 as strings to avoid precision
+			// loss in JS numbers, same as plain int64/uint64 fields.
+			"wrapperspb.Int64Value", reflect.TypeOf(wrapperspb.Int64Value{}), huma.TypeString, "",
+		},
+		{"wrapperspb.UInt64Value", reflect.TypeOf(wrapperspb.UInt64Value{}), huma.TypeString, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := r.Schema(tt.t, false, tt.name)
+			if s.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", s.Type, tt.wantType)
+			}
+			if s.Format != tt.wantFormat {
+				t.Errorf("Format = %q, want %q", s.Format, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestSchemaFromTypeEnum(t *testing.T) {
+	namer := func(t reflect.Type, hint string) string { return t.Name() }
+
+	t.Run("default encodes as string enum", func(t *testing.T) {
+		r := NewRegistry("#/components/schemas/", namer)
+		s := r.Schema(reflect.TypeOf(structpb.NullValue(0)), false, "NullValue")
+
+		if s.Type != huma.TypeString {
+			t.Errorf("Type = %q, want %q", s.Type, huma.TypeString)
+		}
+		if len(s.Enum) != 1 || s.Enum[0] != "NULL_VALUE" {
+			t.Errorf("Enum = %v, want [\"NULL_VALUE\"]", s.Enum)
+		}
+	})
+
+	t.Run("UseEnumNumbers encodes as integer with x-enum-varnames", func(t *testing.T) {
+		r := NewRegistryWithOptions("#/components/schemas/", namer, RegistryOptions{UseEnumNumbers: true})
+		s := r.Schema(reflect.TypeOf(structpb.NullValue(0)), false, "NullValue")
+
+		if s.Type != huma.TypeInteger {
+			t.Errorf("Type = %q, want %q", s.Type, huma.TypeInteger)
+		}
+		if len(s.Enum) != 1 || s.Enum[0] != int64(0) {
+			t.Errorf("Enum = %v, want [0]", s.Enum)
+		}
+		varNames, _ := s.Extensions["x-enum-varnames"].([]string)
+		if len(varNames) != 1 || varNames[0] != "NULL_VALUE" {
+			t.Errorf("Extensions[x-enum-varnames] = %v, want [\"NULL_VALUE\"]", varNames)
+		}
+	})
+}
+
+func TestOneofFieldSchema(t *testing.T) {
+	namer := func(t reflect.Type, hint string) string { return t.Name() }
+	r := NewRegistry("#/components/schemas/", namer)
+
+	// structpb.Value's "kind" oneof covers an enum, a scalar of each
+	// relevant kind, and message-typed variants, without relying on any
+	// particular Get* accessor naming.
+	oneofDesc := (&structpb.Value{}).ProtoReflect().Descriptor().Oneofs().ByName("kind")
+	fields := oneofDesc.Fields()
+
+	tests := []struct {
+		field      string
+		wantType   string
+		wantFormat string
+	}{
+		{"null_value", huma.TypeString, ""},
+		{"number_value", huma.TypeNumber, "double"},
+		{"string_value", huma.TypeString, ""},
+		{"bool_value", huma.TypeBoolean, ""},
+		{"struct_value", huma.TypeObject, ""},
+		{"list_value", huma.TypeArray, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			fd := fields.ByName(protoreflect.Name(tt.field))
+			if fd == nil {
+				t.Fatalf("field %q not found in oneof", tt.field)
+			}
+
+			s := oneofFieldSchema(r, "Value", fd)
+			if s == nil {
+				t.Fatalf("oneofFieldSchema(%q) = nil", tt.field)
+			}
+			if s.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", s.Type, tt.wantType)
+			}
+			if s.Format != tt.wantFormat {
+				t.Errorf("Format = %q, want %q", s.Format, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func propertyNames(s *huma.Schema) []string {
+	names := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		names = append(names, k)
+	}
+	return names
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, v := range haystack {
+		if v == needle {
+			return true
+		}
+	}
+	return false
+}